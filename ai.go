@@ -0,0 +1,111 @@
+/*
+file: ai.go
+author: alemedeiros <alexandre.n.medeiros _at_ gmail.com>
+
+Tunable-strength AI move selection for the nnc package.
+*/
+
+package nnc
+
+import (
+	"errors"
+	"math/rand"
+	"time"
+)
+
+// AIDifficulty selects how strong a PlayAIDifficulty move is.
+type AIDifficulty int
+
+// Easy picks a uniformly random legal move; Normal samples among the
+// strongest few moves found by the search; Hard always plays the
+// deterministic best move.
+const (
+	Easy AIDifficulty = iota
+	Normal
+	Hard
+)
+
+// normalTopN is how many of the best root moves Normal difficulty considers.
+const normalTopN = 3
+
+// normalDelta is how far below the best move's value a candidate can be and
+// still be considered by Normal difficulty.
+const normalDelta = 5
+
+// SeedAI seeds the game's random source, making Easy and Normal difficulty
+// move selection reproducible. Intended for tests; games not explicitly
+// seeded use a time-seeded source on first use.
+func (g *Game) SeedAI(seed int64) {
+	g.rng = rand.New(rand.NewSource(seed))
+}
+
+// PlayAIDifficulty method checks if it is the given player's turn, if so, it
+// makes a move as that player using the requested AIDifficulty.
+//
+// Return true and winner (Empty means draw) if the move ended the game.
+func (g *Game) PlayAIDifficulty(player byte, d AIDifficulty) (done bool, winner byte, err error) {
+	// Validation check
+	if g.currPlayer != player {
+		return false, Empty, errors.New("not player's turn")
+	}
+
+	if g.rng == nil {
+		g.rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	var x, y int
+	switch d {
+	case Easy:
+		x, y = g.randomMove()
+	case Normal:
+		x, y = pickAmongTop(alphaBetaRoot(*g, player), g.rng)
+	default:
+		m := alphaBetaRoot(*g, player)[0]
+		x, y = m.i, m.j
+	}
+
+	return g.Play(x, y, player)
+}
+
+// legalMoves returns the coordinates of every empty cell on the board.
+func (g Game) legalMoves() (ms [][2]int) {
+	for i, l := range g.board {
+		for j, e := range l {
+			if e == Empty {
+				ms = append(ms, [2]int{i, j})
+			}
+		}
+	}
+
+	return
+}
+
+// randomMove picks uniformly among the game's legal moves.
+func (g *Game) randomMove() (int, int) {
+	ms := g.legalMoves()
+	m := ms[g.rng.Intn(len(ms))]
+
+	return m[0], m[1]
+}
+
+// pickAmongTop samples uniformly among the best normalTopN moves whose value
+// is within normalDelta of the best move. moves must be sorted from best to
+// worst, as returned by alphaBetaRoot.
+func pickAmongTop(moves []move, rng *rand.Rand) (int, int) {
+	n := normalTopN
+	if n > len(moves) {
+		n = len(moves)
+	}
+
+	best := moves[0].value
+	candidates := make([]move, 0, n)
+	for _, m := range moves[:n] {
+		if best-m.value <= normalDelta {
+			candidates = append(candidates, m)
+		}
+	}
+
+	pick := candidates[rng.Intn(len(candidates))]
+
+	return pick.i, pick.j
+}
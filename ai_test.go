@@ -0,0 +1,59 @@
+package nnc
+
+import "testing"
+
+func TestPlayAIDifficultyEasyStaysLegal(t *testing.T) {
+	g := New(3)
+	g.SeedAI(1)
+
+	for i := 0; i < 5; i++ {
+		done, _, err := g.PlayAIDifficulty(g.CurrentPlayer(), Easy)
+		if err != nil {
+			t.Fatalf("PlayAIDifficulty: %v", err)
+		}
+		if done {
+			break
+		}
+	}
+}
+
+func TestPlayAIDifficultyHardNeverLoses(t *testing.T) {
+	// Both engines play optimally against themselves, so both independent
+	// games should settle on a draw, even though the exact moves chosen can
+	// differ between the iterative-deepening search behind PlayAI and the
+	// exhaustive search behind PlayAIDifficulty(Hard).
+	if w := playToEnd(t, New(3), func(g *Game, p byte) (bool, byte, error) { return g.PlayAI(p) }); w != Empty {
+		t.Fatalf("expected optimal self-play to draw, got winner %q", w)
+	}
+	if w := playToEnd(t, New(3), func(g *Game, p byte) (bool, byte, error) { return g.PlayAIDifficulty(p, Hard) }); w != Empty {
+		t.Fatalf("expected optimal self-play to draw, got winner %q", w)
+	}
+}
+
+// playToEnd self-plays a game to completion using move, returning the
+// winner (Empty for a draw).
+func playToEnd(t *testing.T, g Game, move func(g *Game, p byte) (bool, byte, error)) byte {
+	t.Helper()
+
+	for i := 0; i < g.rows*g.cols; i++ {
+		done, winner, err := move(&g, g.CurrentPlayer())
+		if err != nil {
+			t.Fatalf("move %d: %v", i, err)
+		}
+		if done {
+			return winner
+		}
+	}
+
+	t.Fatal("game did not end within the board's cell count")
+
+	return Empty
+}
+
+func TestPlayAIDifficultyWrongTurn(t *testing.T) {
+	g := New(3)
+
+	if _, _, err := g.PlayAIDifficulty(Nought, Easy); err == nil {
+		t.Fatal("expected error when playing out of turn")
+	}
+}
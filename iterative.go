@@ -0,0 +1,54 @@
+/*
+file: iterative.go
+author: alemedeiros <alexandre.n.medeiros _at_ gmail.com>
+
+Iterative-deepening search: searches depth 1, 2, 3, ... re-using a single
+transposition table across iterations, until a time budget expires.
+*/
+
+package nnc
+
+import (
+	"errors"
+	"time"
+)
+
+// defaultAIBudget is the time budget PlayAI gives PlayAITimed.
+const defaultAIBudget = 2 * time.Second
+
+// iterativeDeepening searches g one depth at a time, from 1 up to the
+// number of empty cells, reusing tt across iterations for move ordering and
+// cutoffs. It returns the best move found by the deepest iteration it ran.
+// The deadline is only checked between iterations, so that last iteration
+// always runs to completion, even if it finishes after the deadline.
+func iterativeDeepening(g Game, player byte, budget time.Duration) move {
+	deadline := time.Now().Add(budget)
+	tt := newTranspositionTable()
+
+	lim := searchBound(g)
+	best := move{-lim, -1, -1}
+
+	for depth := 1; depth <= g.count; depth++ {
+		best = alphaBetaTT(g, depth, -lim, lim, -1, -1, player, tt)
+
+		if time.Now().After(deadline) {
+			break
+		}
+	}
+
+	return best
+}
+
+// PlayAITimed method checks if it is the given player's turn, if so, it
+// makes a move as that player found by iterative deepening within budget.
+//
+// Return true and winner (Empty means draw) if the move ended the game.
+func (g *Game) PlayAITimed(player byte, budget time.Duration) (done bool, winner byte, err error) {
+	if g.currPlayer != player {
+		return false, Empty, errors.New("not player's turn")
+	}
+
+	m := iterativeDeepening(*g, player, budget)
+
+	return g.Play(m.i, m.j, player)
+}
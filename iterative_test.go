@@ -0,0 +1,39 @@
+package nnc
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPlayAITimedFindsImmediateWin(t *testing.T) {
+	g := New(3)
+	g.Play(0, 0, Cross)
+	g.Play(1, 0, Nought)
+	g.Play(0, 1, Cross)
+	g.Play(1, 1, Nought)
+
+	done, winner, err := g.PlayAITimed(Cross, 200*time.Millisecond)
+	if err != nil {
+		t.Fatalf("PlayAITimed: %v", err)
+	}
+	if !done || winner != Cross {
+		t.Fatalf("expected Cross to find the immediate win, got done=%v winner=%q", done, winner)
+	}
+}
+
+func TestZobristHashDependsOnMovesPlayed(t *testing.T) {
+	g1, g2 := New(3), New(3)
+	if g1.hash != g2.hash {
+		t.Fatal("two fresh games of the same size should hash the same")
+	}
+
+	g1.Play(0, 0, Cross)
+	if g1.hash == g2.hash {
+		t.Fatal("playing a move should change the hash")
+	}
+
+	g2.Play(1, 1, Cross)
+	if g1.hash == g2.hash {
+		t.Fatal("different moves should (almost certainly) hash differently")
+	}
+}
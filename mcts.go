@@ -0,0 +1,213 @@
+/*
+file: mcts.go
+author: alemedeiros <alexandre.n.medeiros _at_ gmail.com>
+
+Monte-Carlo Tree Search (UCT) as an alternative to alpha-beta: since it
+never evaluates the outcome heuristic, it degrades gracefully on boards
+alpha-beta can't search exhaustively.
+*/
+
+package nnc
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// uctC is the exploration constant used by the UCT selection rule.
+var uctC = math.Sqrt2
+
+// mctsNode is a node of the search tree built by PlayAIMCTS.
+type mctsNode struct {
+	g            Game
+	move         [2]int // the move that produced this node from its parent
+	parent       *mctsNode
+	children     []*mctsNode
+	untriedMoves [][2]int
+
+	// playerJustMoved is the player who made the move leading to g, i.e.
+	// whose perspective wins/visits are recorded from.
+	playerJustMoved byte
+
+	wins   float64
+	visits int
+}
+
+func newMCTSNode(g Game, move [2]int, parent *mctsNode, playerJustMoved byte) *mctsNode {
+	return &mctsNode{
+		g:               g,
+		move:            move,
+		parent:          parent,
+		untriedMoves:    g.legalMoves(),
+		playerJustMoved: playerJustMoved,
+	}
+}
+
+// selectChild picks the child maximizing the UCT score.
+func (n *mctsNode) selectChild() *mctsNode {
+	best := n.children[0]
+	bestScore := math.Inf(-1)
+
+	for _, c := range n.children {
+		score := c.wins/float64(c.visits) + uctC*math.Sqrt(math.Log(float64(n.visits))/float64(c.visits))
+		if score > bestScore {
+			best, bestScore = c, score
+		}
+	}
+
+	return best
+}
+
+// expand pops a random untried move, applies it, and adds the resulting
+// child to n.
+func (n *mctsNode) expand(rng *rand.Rand) *mctsNode {
+	idx := rng.Intn(len(n.untriedMoves))
+	m := n.untriedMoves[idx]
+	n.untriedMoves = append(n.untriedMoves[:idx], n.untriedMoves[idx+1:]...)
+
+	mover := n.g.currPlayer
+
+	ng := n.g.copyGame()
+	ng.Play(m[0], m[1], mover)
+
+	child := newMCTSNode(ng, m, n, mover)
+	n.children = append(n.children, child)
+
+	return child
+}
+
+// update records the result of a rollout at this node.
+func (n *mctsNode) update(result float64) {
+	n.visits++
+	n.wins += result
+}
+
+// rollout plays uniformly random legal moves from g, only ever consulting
+// isDone (never the outcome heuristic), until the game ends, and returns the
+// winner (Empty for a draw).
+//
+// g is copied before play begins: g.board's backing arrays are shared with
+// the caller's tree node, and Play mutates board in place, so rolling out on
+// the original g would corrupt that node's board and untriedMoves.
+func rollout(g Game, rng *rand.Rand) byte {
+	g = g.copyGame()
+
+	for {
+		if done, winner := g.isDone(); done {
+			return winner
+		}
+
+		ms := g.legalMoves()
+		m := ms[rng.Intn(len(ms))]
+		g.Play(m[0], m[1], g.currPlayer)
+	}
+}
+
+// rolloutResult scores a rollout's winner from playerJustMoved's
+// perspective: 1 for a win, 0.5 for a draw, 0 for a loss.
+func rolloutResult(winner, playerJustMoved byte) float64 {
+	switch winner {
+	case Empty:
+		return 0.5
+	case playerJustMoved:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func oppositePlayer(player byte) byte {
+	if player == Cross {
+		return Nought
+	}
+
+	return Cross
+}
+
+// mctsSearch grows a UCT tree rooted at g, one iteration (selection,
+// expansion, simulation, backpropagation) at a time, until shouldContinue
+// reports false, then returns the root.
+func mctsSearch(g Game, rng *rand.Rand, shouldContinue func() bool) *mctsNode {
+	root := newMCTSNode(g, [2]int{-1, -1}, nil, oppositePlayer(g.currPlayer))
+
+	for shouldContinue() {
+		node := root
+
+		// Selection
+		for len(node.untriedMoves) == 0 && len(node.children) > 0 {
+			node = node.selectChild()
+		}
+
+		// Expansion
+		if len(node.untriedMoves) > 0 {
+			node = node.expand(rng)
+		}
+
+		// Simulation
+		winner := rollout(node.g, rng)
+
+		// Backpropagation
+		for n := node; n != nil; n = n.parent {
+			n.update(rolloutResult(winner, n.playerJustMoved))
+		}
+	}
+
+	return root
+}
+
+// robustChild returns root's child with the most visits.
+func robustChild(root *mctsNode) *mctsNode {
+	best := root.children[0]
+	for _, c := range root.children {
+		if c.visits > best.visits {
+			best = c
+		}
+	}
+
+	return best
+}
+
+// PlayAIMCTS method checks if it is the given player's turn, if so, it plays
+// the move found by running iterations of Monte-Carlo Tree Search (UCT).
+//
+// Return true and winner (Empty means draw) if the move ended the game.
+func (g *Game) PlayAIMCTS(player byte, iterations int) (done bool, winner byte, err error) {
+	if g.currPlayer != player {
+		return false, Empty, errors.New("not player's turn")
+	}
+
+	if g.rng == nil {
+		g.rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	iter := 0
+	root := mctsSearch(*g, g.rng, func() bool {
+		iter++
+		return iter <= iterations
+	})
+
+	m := robustChild(root)
+
+	return g.Play(m.move[0], m.move[1], player)
+}
+
+// PlayAIMCTSTimed is PlayAIMCTS but bounded by a time budget instead of a
+// fixed iteration count, running as many iterations as fit within budget.
+func (g *Game) PlayAIMCTSTimed(player byte, budget time.Duration) (done bool, winner byte, err error) {
+	if g.currPlayer != player {
+		return false, Empty, errors.New("not player's turn")
+	}
+
+	if g.rng == nil {
+		g.rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	deadline := time.Now().Add(budget)
+	root := mctsSearch(*g, g.rng, func() bool { return time.Now().Before(deadline) })
+
+	m := robustChild(root)
+
+	return g.Play(m.move[0], m.move[1], player)
+}
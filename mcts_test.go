@@ -0,0 +1,35 @@
+package nnc
+
+import "testing"
+
+func TestPlayAIMCTSFindsImmediateWin(t *testing.T) {
+	g := New(3)
+	g.SeedAI(1)
+	g.Play(0, 0, Cross)
+	g.Play(1, 0, Nought)
+	g.Play(0, 1, Cross)
+	g.Play(1, 1, Nought)
+
+	done, winner, err := g.PlayAIMCTS(Cross, 2000)
+	if err != nil {
+		t.Fatalf("PlayAIMCTS: %v", err)
+	}
+	if !done || winner != Cross {
+		t.Fatalf("expected Cross to find the immediate win, got done=%v winner=%q", done, winner)
+	}
+}
+
+func TestPlayAIMCTSOnLargeBoard(t *testing.T) {
+	// Alpha-beta would be intractable here; MCTS just needs to complete and
+	// play a legal move.
+	g := NewMNK(7, 7, 4)
+	g.SeedAI(1)
+
+	done, _, err := g.PlayAIMCTS(Cross, 200)
+	if err != nil {
+		t.Fatalf("PlayAIMCTS: %v", err)
+	}
+	if done {
+		t.Fatal("a single move on an empty 7x7 board shouldn't end the game")
+	}
+}
@@ -0,0 +1,213 @@
+/*
+file: menace.go
+author: alemedeiros <alexandre.n.medeiros _at_ gmail.com>
+
+A MENACE-style (Machine Educable Noughts and Crosses Engine) reinforcement
+learning AI for the nnc package. Instead of searching, it learns a bead count
+per (state, move) pair from self-play and slowly biases its moves towards
+whatever has historically won.
+*/
+
+// Package menace implements a MENACE-style reinforcement learning player for
+// nnc, an alternative to alpha-beta search for boards where exhaustive
+// search is intractable.
+package menace
+
+import (
+	"encoding/gob"
+	"io"
+	"math/rand"
+
+	"github.com/alemedeiros/nnc"
+)
+
+// initialBeads is the starting bead count for a move in a newly seen state,
+// decayed as the game progresses so mid/late-game states start smaller.
+func initialBeads(filled, size int) int {
+	const base = 4
+
+	b := base - filled/size
+	if b < 1 {
+		b = 1
+	}
+
+	return b
+}
+
+// Bead reward/penalty constants applied on LearnResult.
+const (
+	winBeads  = 3
+	lossBeads = -1
+	drawBeads = 1
+
+	// minBeads is the floor a (state, move) bead count is clamped to after a
+	// loss, so a move is never removed from consideration entirely.
+	minBeads = 1
+)
+
+// ply records one (state, move) decision made during a game, so it can be
+// rewarded once the game's outcome is known.
+type ply struct {
+	key    string
+	move   [2]int
+	player byte
+}
+
+// MenaceAI is a MENACE-style reinforcement learning player. Use New to
+// create one, Move (or Train) to play, and LearnResult to reward the moves
+// played since the last call to LearnResult.
+type MenaceAI struct {
+	size  int
+	rng   *rand.Rand
+	beads map[string]map[[2]int]int
+
+	trail []ply
+}
+
+// New creates a MenaceAI for a size x size board with no prior experience.
+func New(size int) *MenaceAI {
+	return &MenaceAI{
+		size:  size,
+		rng:   rand.New(rand.NewSource(1)),
+		beads: make(map[string]map[[2]int]int),
+	}
+}
+
+// Move implements the nnc.Player interface: it canonicalizes g's board,
+// samples a move proportionally to the recorded bead counts (seeding a new
+// state on first sight), and records the decision so LearnResult can later
+// reward it.
+func (m *MenaceAI) Move(g nnc.Game) (x, y int) {
+	board := g.Board()
+	player := g.CurrentPlayer()
+
+	key, sym := canonicalKey(board)
+	moves := canonicalMoves(board, sym)
+
+	state, ok := m.beads[key]
+	if !ok {
+		filled := 0
+		for _, row := range board {
+			for _, c := range row {
+				if c != nnc.Empty {
+					filled++
+				}
+			}
+		}
+
+		state = make(map[[2]int]int, len(moves))
+		for _, cm := range moves {
+			state[cm] = initialBeads(filled, m.size)
+		}
+		m.beads[key] = state
+	}
+
+	cm := sampleMove(state, moves, m.rng)
+	m.trail = append(m.trail, ply{key: key, move: cm, player: player})
+
+	ox, oy := fromCanonical(cm[0], cm[1], sym, m.size)
+
+	return ox, oy
+}
+
+// sampleMove picks one of moves, weighted by its bead count in state.
+func sampleMove(state map[[2]int]int, moves [][2]int, rng *rand.Rand) [2]int {
+	total := 0
+	for _, cm := range moves {
+		total += state[cm]
+	}
+
+	pick := rng.Intn(total)
+	for _, cm := range moves {
+		pick -= state[cm]
+		if pick < 0 {
+			return cm
+		}
+	}
+
+	// Unreachable unless total was 0, which can't happen since every move
+	// starts with at least minBeads beads.
+	return moves[0]
+}
+
+// LearnResult rewards or punishes every (state, move) pair played since the
+// last LearnResult call, then clears the trail for the next game.
+func (m *MenaceAI) LearnResult(winner byte) {
+	for _, p := range m.trail {
+		d := drawBeads
+		switch {
+		case winner == p.player:
+			d = winBeads
+		case winner != nnc.Empty:
+			d = lossBeads
+		}
+
+		state := m.beads[p.key]
+		state[p.move] += d
+		if state[p.move] < minBeads {
+			state[p.move] = minBeads
+		}
+	}
+
+	m.trail = m.trail[:0]
+}
+
+// Train plays iterations games against opponent (or itself, if opponent is
+// nil), alternating who starts, rewarding itself after every game via
+// LearnResult.
+func (m *MenaceAI) Train(iterations int, opponent nnc.Player) {
+	if opponent == nil {
+		opponent = m
+	}
+
+	for i := 0; i < iterations; i++ {
+		g := nnc.New(m.size)
+
+		first, second := nnc.Player(m), opponent
+		if i%2 == 1 {
+			first, second = opponent, m
+		}
+
+		for {
+			cur := first
+			if g.CurrentPlayer() == nnc.Nought {
+				cur = second
+			}
+
+			x, y := cur.Move(g)
+			done, winner, err := g.Play(x, y, g.CurrentPlayer())
+			if err != nil {
+				break
+			}
+			if done {
+				m.LearnResult(winner)
+				break
+			}
+		}
+	}
+}
+
+// Save gob-encodes the trained brain to w.
+func (m *MenaceAI) Save(w io.Writer) error {
+	return gob.NewEncoder(w).Encode(struct {
+		Size  int
+		Beads map[string]map[[2]int]int
+	}{m.size, m.beads})
+}
+
+// Load replaces m's trained brain with the one gob-decoded from r.
+func (m *MenaceAI) Load(r io.Reader) error {
+	var data struct {
+		Size  int
+		Beads map[string]map[[2]int]int
+	}
+
+	if err := gob.NewDecoder(r).Decode(&data); err != nil {
+		return err
+	}
+
+	m.size = data.Size
+	m.beads = data.Beads
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package menace
+
+import (
+	"testing"
+
+	"github.com/alemedeiros/nnc"
+)
+
+// TestTrainConvergesAgainstAlphaBeta trains a MenaceAI against the (perfect)
+// alpha-beta AI on a 3x3 board and checks that it has learned to avoid
+// losing most of the time, even though alpha-beta never loses itself.
+func TestTrainConvergesAgainstAlphaBeta(t *testing.T) {
+	m := New(3)
+	opp := nnc.AlphaBetaPlayer{}
+
+	m.Train(3000, opp)
+
+	const evalGames = 40
+	nonLosses := 0
+
+	for i := 0; i < evalGames; i++ {
+		g := nnc.New(3)
+
+		first, second := nnc.Player(m), nnc.Player(opp)
+		menaceSide := nnc.Cross
+		if i%2 == 1 {
+			first, second = opp, m
+			menaceSide = nnc.Nought
+		}
+
+		opponentSide := nnc.Cross
+		if menaceSide == nnc.Cross {
+			opponentSide = nnc.Nought
+		}
+
+		var winner byte
+		for {
+			cur := first
+			if g.CurrentPlayer() == nnc.Nought {
+				cur = second
+			}
+
+			x, y := cur.Move(g)
+
+			done, w, err := g.Play(x, y, g.CurrentPlayer())
+			if err != nil {
+				t.Fatalf("Play: %v", err)
+			}
+			if done {
+				winner = w
+				m.LearnResult(w)
+				break
+			}
+		}
+
+		if winner != opponentSide {
+			nonLosses++
+		}
+	}
+
+	if rate := float64(nonLosses) / evalGames; rate <= 0.5 {
+		t.Fatalf("non-loss rate too low after training: got %.2f, want > 0.50", rate)
+	}
+}
@@ -0,0 +1,87 @@
+/*
+file: symmetry.go
+author: alemedeiros <alexandre.n.medeiros _at_ gmail.com>
+
+Canonicalization of a square board under the 8 symmetries of the dihedral
+group D4 (identity, 3 rotations, 4 reflections), so MENACE treats board
+states that only differ by rotation/reflection as the same state.
+*/
+
+package menace
+
+import "github.com/alemedeiros/nnc"
+
+// invSym maps each symmetry index to the index of its inverse.
+var invSym = [8]int{0, 3, 2, 1, 4, 5, 6, 7}
+
+// transform maps coordinate (i, j) on an n x n board to its image under
+// symmetry sym:
+//
+//	0 identity      1 rotate90 cw    2 rotate180     3 rotate270 cw
+//	4 flip horiz.   5 flip vert.     6 transpose     7 anti-transpose
+func transform(sym, i, j, n int) (ni, nj int) {
+	switch sym {
+	case 0:
+		return i, j
+	case 1:
+		return j, n - 1 - i
+	case 2:
+		return n - 1 - i, n - 1 - j
+	case 3:
+		return n - 1 - j, i
+	case 4:
+		return i, n - 1 - j
+	case 5:
+		return n - 1 - i, j
+	case 6:
+		return j, i
+	default: // 7
+		return n - 1 - j, n - 1 - i
+	}
+}
+
+// canonicalKey returns a flattened-board string key for the lexicographically
+// smallest of board's 8 symmetric images, along with the symmetry that
+// produced it.
+func canonicalKey(board [][]byte) (key string, sym int) {
+	n := len(board)
+
+	for s := 0; s < 8; s++ {
+		buf := make([]byte, n*n)
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				ni, nj := transform(s, i, j, n)
+				buf[ni*n+nj] = board[i][j]
+			}
+		}
+
+		if k := string(buf); key == "" || k < key {
+			key, sym = k, s
+		}
+	}
+
+	return
+}
+
+// canonicalMoves returns board's legal moves translated into the coordinate
+// system of symmetry sym.
+func canonicalMoves(board [][]byte, sym int) (moves [][2]int) {
+	n := len(board)
+
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if board[i][j] == nnc.Empty {
+				ni, nj := transform(sym, i, j, n)
+				moves = append(moves, [2]int{ni, nj})
+			}
+		}
+	}
+
+	return
+}
+
+// fromCanonical translates a move chosen in the coordinate system of
+// symmetry sym back to the original board's coordinates.
+func fromCanonical(ci, cj, sym, n int) (i, j int) {
+	return transform(invSym[sym], ci, cj, n)
+}
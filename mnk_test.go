@@ -0,0 +1,80 @@
+package nnc
+
+import "testing"
+
+func TestNewMNKClassic(t *testing.T) {
+	g := NewMNK(3, 3, 3)
+
+	g.Play(0, 0, Cross)
+	g.Play(1, 0, Nought)
+	g.Play(0, 1, Cross)
+	g.Play(1, 1, Nought)
+	done, winner, err := g.Play(0, 2, Cross)
+
+	if err != nil {
+		t.Fatalf("Play: %v", err)
+	}
+	if !done || winner != Cross {
+		t.Fatalf("expected Cross to win the top row, got done=%v winner=%q", done, winner)
+	}
+}
+
+func TestNewMNKConnectFourLike(t *testing.T) {
+	g := NewMNK(7, 6, 4)
+
+	moves := [][2]int{
+		{5, 0}, {5, 1}, // Cross, Nought
+		{4, 0}, {5, 2},
+		{3, 0}, {5, 3},
+	}
+
+	var done bool
+	var winner byte
+	var err error
+	for i, m := range moves {
+		player := Cross
+		if i%2 == 1 {
+			player = Nought
+		}
+		done, winner, err = g.Play(m[0], m[1], player)
+		if err != nil {
+			t.Fatalf("move %d: %v", i, err)
+		}
+	}
+
+	done, winner, err = g.Play(2, 0, Cross)
+	if err != nil {
+		t.Fatalf("Play: %v", err)
+	}
+	if !done || winner != Cross {
+		t.Fatalf("expected Cross to win with 4 vertically, got done=%v winner=%q", done, winner)
+	}
+}
+
+func TestNewMNKSmallGomoku(t *testing.T) {
+	g := NewMNK(7, 7, 4)
+
+	for i := 0; i < 3; i++ {
+		if _, _, err := g.Play(0, i, Cross); err != nil {
+			t.Fatalf("Cross move %d: %v", i, err)
+		}
+		if _, _, err := g.Play(1, i, Nought); err != nil {
+			t.Fatalf("Nought move %d: %v", i, err)
+		}
+	}
+
+	done, winner, err := g.Play(0, 3, Cross)
+	if err != nil {
+		t.Fatalf("Play: %v", err)
+	}
+	if !done || winner != Cross {
+		t.Fatalf("expected Cross to win with 4 in a row, got done=%v winner=%q", done, winner)
+	}
+}
+
+func TestNewIsNewMNKWrapper(t *testing.T) {
+	g := New(4)
+	if g.rows != 4 || g.cols != 4 || g.k != 4 {
+		t.Fatalf("New(4) should be equivalent to NewMNK(4, 4, 4), got rows=%d cols=%d k=%d", g.rows, g.cols, g.k)
+	}
+}
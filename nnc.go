@@ -6,12 +6,20 @@ A n-sized noughts and crosses game library.
 
 It is a generalization of noughts and crosses, with a n x n board.
 To win, you have to fill a line, column or diagonal with your symbol.
+
+More generally, it implements m,n,k-games: an m x n board where winning
+requires k in a row horizontally, vertically, or on either diagonal. See
+NewMNK.
 */
 
 // Package nnc implements a n-sized noughts and crosses game.
 package nnc
 
-import "errors"
+import (
+	"errors"
+	"math/rand"
+	"sort"
+)
 
 // Empty is an unplayed square;
 // Cross is a 'X';
@@ -22,12 +30,25 @@ const (
 	Nought byte = 'O'
 )
 
-// A Game is a game board, use New function to initialize a Game.
+// A Game is a game board, use New or NewMNK to initialize a Game.
 type Game struct {
 	board      [][]byte
-	size       int
+	rows, cols int
+	k          int
 	count      int
 	currPlayer byte
+
+	// rng backs the AI difficulty levels that need randomness. It is
+	// initialized lazily so that a zero-value New game still works, and can
+	// be seeded with SeedAI for reproducible tests.
+	rng *rand.Rand
+
+	// zobrist and hash back the transposition table used by PlayAITimed.
+	// zobrist is precomputed once in New/NewMNK and shared (read-only) by
+	// every Game derived from it via copyGame; hash is updated
+	// incrementally by Play as pieces are placed.
+	zobrist [][][2]uint64
+	hash    uint64
 }
 
 // Structure to save the move and its value.
@@ -42,10 +63,10 @@ func (g Game) CurrentPlayer() byte {
 
 // Board method returns a copy of the current state of the board.
 func (g Game) Board() (board [][]byte) {
-	board = make([][]byte, g.size)
+	board = make([][]byte, g.rows)
 
 	for i := range board {
-		board[i] = make([]byte, g.size)
+		board[i] = make([]byte, g.cols)
 		copy(board[i], g.board[i])
 	}
 
@@ -70,20 +91,33 @@ func max(a, b move) move {
 	}
 }
 
-// New function Initializes a game structure with a sz-sized board.
+// New function Initializes a game structure with a sz-sized board, a win
+// requiring a full row/column/diagonal.
+//
+// It is a convenience wrapper around NewMNK(sz, sz, sz). First player is
+// always Cross.
+func New(sz int) Game {
+	return NewMNK(sz, sz, sz)
+}
+
+// NewMNK function initializes a m x n board where the winning condition is
+// any k marks in a row, horizontally, vertically, or on either diagonal.
 // First player is always Cross.
-func New(sz int) (g Game) {
+func NewMNK(m, n, k int) (g Game) {
 	// Allocate a new Game structure
 	g = Game{
-		board:      make([][]byte, sz),
-		size:       sz,
-		count:      sz * sz,
+		board:      make([][]byte, m),
+		rows:       m,
+		cols:       n,
+		k:          k,
+		count:      m * n,
 		currPlayer: Cross, // First player is Cross
+		zobrist:    newZobristTable(m, n),
 	}
 
 	// Initialize board.
 	for i := range g.board {
-		g.board[i] = make([]byte, sz)
+		g.board[i] = make([]byte, n)
 		for j := range g.board[i] {
 			g.board[i][j] = Empty
 		}
@@ -96,15 +130,19 @@ func New(sz int) (g Game) {
 func (g Game) copyGame() (ng Game) {
 	// Allocate a new Game structure
 	ng = Game{
-		board:      make([][]byte, g.size),
-		size:       g.size,
+		board:      make([][]byte, g.rows),
+		rows:       g.rows,
+		cols:       g.cols,
+		k:          g.k,
 		count:      g.count,
 		currPlayer: g.currPlayer,
+		zobrist:    g.zobrist, // read-only, safe to share
+		hash:       g.hash,
 	}
 
 	// Copy board.
 	for i := range ng.board {
-		ng.board[i] = make([]byte, g.size)
+		ng.board[i] = make([]byte, g.cols)
 		for j := range ng.board[i] {
 			ng.board[i][j] = g.board[i][j]
 		}
@@ -122,7 +160,7 @@ func (g *Game) Play(x, y int, player byte) (done bool, winner byte, err error) {
 	if g.currPlayer != player {
 		return false, Empty, errors.New("not player's turn")
 	}
-	if x < 0 || g.size <= x || y < 0 || g.size <= y {
+	if x < 0 || g.rows <= x || y < 0 || g.cols <= y {
 		return false, Empty, errors.New("invalid position")
 	}
 	if g.board[x][y] != Empty {
@@ -132,6 +170,9 @@ func (g *Game) Play(x, y int, player byte) (done bool, winner byte, err error) {
 
 	// Move is valid, do it!
 	g.board[x][y] = player
+	if g.zobrist != nil {
+		g.hash ^= g.zobrist[x][y][playerIndex(player)]
+	}
 
 	// Check if move ended the game
 	isDone, winner := g.isDone()
@@ -146,28 +187,37 @@ func (g *Game) Play(x, y int, player byte) (done bool, winner byte, err error) {
 // that player.
 //
 // Return true and winner (Empty means draw) if the move ended the game.
+//
+// PlayAI searches within a sensible default time budget; see PlayAITimed to
+// choose the budget explicitly, and PlayAIDifficulty for weaker AI levels.
 func (g *Game) PlayAI(player byte) (done bool, winner byte, err error) {
-	// Validation check
-	if g.currPlayer != player {
-		return false, Empty, errors.New("not player's turn")
-	}
+	return g.PlayAITimed(player, defaultAIBudget)
+}
 
-	// A value greater than the maximum value possible for a game.
-	lim := g.size * g.size * 10
+// alphaBetaRoot runs the serial alpha-beta search for every legal move of
+// player at the root and returns the resulting moves ordered from best to
+// worst. It is the building block for PlayAIDifficulty and AlphaBetaPlayer.
+func alphaBetaRoot(g Game, player byte) []move {
+	lim := searchBound(g)
 
-	// Serial alpha-beta pruning
-	m := alphaBetaPruningSerial(*g, g.size*g.size, -lim, lim, -1, -1, player)
+	var moves []move
+	for i, l := range g.board {
+		for j, e := range l {
+			if e != Empty {
+				continue
+			}
 
-	//res := make(chan move)
-	//prune := make(chan struct{})
-	//defer close(prune)
+			ng := g.copyGame()
+			ng.Play(i, j, player)
 
-	//go alphaBetaPruning(*g, g.size*g.size, -lim, lim, -1, -1, player, res, prune)
+			m := alphaBetaPruningSerial(ng, g.rows*g.cols-1, -lim, lim, i, j, player)
+			moves = append(moves, move{m.value, i, j})
+		}
+	}
 
-	//// Wait for result.
-	//m := <-res
+	sort.Slice(moves, func(a, b int) bool { return moves[a].value > moves[b].value })
 
-	return g.Play(m.i, m.j, player)
+	return moves
 }
 
 // Serial implementation of Alpha-Beta Pruning algorithm.
@@ -259,240 +309,157 @@ func (g *Game) updateTurn() error {
 	return nil
 }
 
+// windowDirs are the 4 directions a k-in-a-row window can run in: a window
+// and its opposite (e.g. left-to-right and right-to-left) are equivalent, so
+// only one representative per axis is listed.
+var windowDirs = [4][2]int{{0, 1}, {1, 0}, {1, 1}, {1, -1}}
+
+// windowEnd returns the last cell of the k-long window starting at (i, j) in
+// direction (di, dj), and whether that window fits on the board.
+func (g Game) windowEnd(i, j, di, dj int) (ei, ej int, ok bool) {
+	ei, ej = i+di*(g.k-1), j+dj*(g.k-1)
+
+	return ei, ej, ei >= 0 && ei < g.rows && ej >= 0 && ej < g.cols
+}
+
 // isDone method determines if the game is over, and if it is, its winner.
 // If winner is Empty, the it was a draw.
 func (g Game) isDone() (done bool, winner byte) {
-	winner = Empty
-	done = true
-	var local bool
-	var init byte
-
-	// Check for winner
-	for i, sz := 0, g.size; i < sz; i++ {
-		// Lines
-		local = true
-		init = Empty
-		for j := 0; j < sz && local; j++ {
-			if j == 0 {
-				init = g.board[i][j]
-			}
-
-			if g.board[i][j] == Empty || g.board[i][j] != init {
-				local = false
+	for i := 0; i < g.rows; i++ {
+		for j := 0; j < g.cols; j++ {
+			if g.board[i][j] == Empty {
+				continue
 			}
-		}
-
-		// Return if someone won
-		if local {
-			return local, init
-		}
 
-		// Columns
-		local = true
-		init = Empty
-		for j := 0; j < sz && local; j++ {
-			if j == 0 {
-				init = g.board[j][i]
-			}
+			for _, d := range windowDirs {
+				if _, _, ok := g.windowEnd(i, j, d[0], d[1]); !ok {
+					continue
+				}
 
-			if g.board[j][i] == Empty || g.board[j][i] != init {
-				local = false
+				if w, won := g.windowWinner(i, j, d[0], d[1]); won {
+					return true, w
+				}
 			}
 		}
-
-		// Return if someone won
-		if local {
-			return local, init
-		}
 	}
 
-	// Diagonal
-	local = true
-	init = Empty
-	for i, sz := 0, g.size; i < sz && local; i++ {
-		if i == 0 {
-			init = g.board[i][i]
-		}
-
-		if g.board[i][i] == Empty || g.board[i][i] != init {
-			local = false
+	// No winner: the game is over only once the board is full. g.count
+	// isn't usable here: Play calls isDone before decrementing it, so the
+	// move that fills the last cell would still see a stale non-zero count.
+	for _, row := range g.board {
+		for _, c := range row {
+			if c == Empty {
+				return false, Empty
+			}
 		}
 	}
 
-	// Return if someone won
-	if local {
-		return local, init
-	}
-
-	// Anti-diagonal
-	local = true
-	init = Empty
-	for i, sz := 0, g.size; i < sz && local; i++ {
-		if i == 0 {
-			init = g.board[i][sz-1-i]
-		}
-
-		if g.board[i][sz-1-i] == Empty || g.board[i][sz-1-i] != init {
-			local = false
-		}
-	}
+	return true, Empty
+}
 
-	// Return if someone won
-	if local {
-		return local, init
-	}
+// windowWinner reports whether every cell of the k-long window starting at
+// (i, j) in direction (di, dj) holds the same non-empty symbol, and if so,
+// which one.
+func (g Game) windowWinner(i, j, di, dj int) (symbol byte, won bool) {
+	symbol = g.board[i][j]
 
-	// Check for draw
-outerFor:
-	for i := range g.board {
-		for _, p := range g.board[i] {
-			if p == Empty {
-				done = false
-				break outerFor
-			}
+	for s := 1; s < g.k; s++ {
+		if g.board[i+di*s][j+dj*s] != symbol {
+			return Empty, false
 		}
 	}
 
-	return
+	return symbol, true
 }
 
 // Outcome calculates the outcome function for a player (Nought/Cross) for the
-// current game.
+// current game: the sum, over every k-long window on the board, of a score
+// rewarding windows that only contain player's marks (and punishing windows
+// that only contain the opponent's).
 func (g Game) outcome(player byte) (sum int) {
 	if player != Nought && player != Cross {
 		return
 	}
 
-	for i, sz := 0, g.size; i < sz; i++ {
-		// Lines
-		linit, lsum := Empty, 0
-		for j := 0; j < sz; j++ {
-			// Empty squares don't change the outcome function.
-			if g.board[i][j] == Empty {
-				continue
-			}
-
-			// Initialize initial symbol.
-			if linit == Empty {
-				linit = g.board[i][j]
-			}
-
-			// Different symbols means line sum is 0.
-			if g.board[i][j] != linit {
-				lsum = 0
-				break
-			}
-
-			if g.board[i][j] == player {
-				lsum += 1 // Increment for player
-			} else {
-				lsum -= 1 // Decrement for opponent
-			}
-		}
-
-		// Colums
-		cinit, csum := Empty, 0
-		for j := 0; j < sz; j++ {
-			// Empty squares don't change the outcome function.
-			if g.board[j][i] == Empty {
-				continue
-			}
-
-			// Initialize initial symbol.
-			if cinit == Empty {
-				cinit = g.board[j][i]
-			}
-
-			// Different symbols means column sum is 0.
-			if g.board[j][i] != cinit {
-				csum = 0
-				break
-			}
+	for i := 0; i < g.rows; i++ {
+		for j := 0; j < g.cols; j++ {
+			for _, d := range windowDirs {
+				if _, _, ok := g.windowEnd(i, j, d[0], d[1]); !ok {
+					continue
+				}
 
-			if g.board[j][i] == player {
-				csum += 1 // Increment for player
-			} else {
-				csum -= 1 // Decrement for opponent
+				sum += g.windowScore(i, j, d[0], d[1], player)
 			}
 		}
-
-		if lsum == sz || csum == sz {
-			return 3 * sz * sz
-		} else if lsum == -sz || csum == -sz {
-			return -(3 * sz * sz)
-		}
-
-		sum += lsum + csum
 	}
 
-	// Diagonal
-	dinit, dsum := Empty, 0
-	for i, sz := 0, g.size; i < sz; i++ {
-
-		// Empty squares don't change the outcome function.
-		if g.board[i][i] == Empty {
-			continue
-		}
-
-		// Initialize initial symbol.
-		if dinit == Empty {
-			dinit = g.board[i][i]
-		}
-
-		// Different symbols means diagonal sum is 0.
-		if g.board[i][i] != dinit {
-			dsum = 0
-			break
-		}
+	return
+}
 
-		if g.board[i][i] == player {
-			dsum += 1 // Increment for player
-		} else {
-			dsum -= 1 // Decrement for opponent
+// windowScore scores the k-long window starting at (i, j) in direction
+// (di, dj) from player's perspective: windows mixing both players score 0;
+// windows with only player's marks score positively (more marks is better,
+// a full window is decisive), windows with only the opponent's score the
+// mirrored negative amount.
+func (g Game) windowScore(i, j, di, dj int, player byte) int {
+	var mine, theirs int
+
+	for s := 0; s < g.k; s++ {
+		switch c := g.board[i+di*s][j+dj*s]; c {
+		case Empty:
+		case player:
+			mine++
+		default:
+			theirs++
 		}
 	}
 
-	if dsum == g.size {
-		return 3 * g.size * g.size
-	} else if dsum == -g.size {
-		return -(3 * g.size * g.size)
+	switch {
+	case mine > 0 && theirs > 0:
+		return 0
+	case mine > 0:
+		return windowMarkScore(mine, g.k)
+	case theirs > 0:
+		return -windowMarkScore(theirs, g.k)
+	default:
+		return 0
 	}
+}
 
-	sum += dsum
-
-	// Anti-Diagonal
-	adinit, adsum := Empty, 0
-	for i, sz := 0, g.size; i < sz; i++ {
-		// Empty squares don't change the outcome function.
-		if g.board[i][sz-1-i] == Empty {
-			continue
-		}
-
-		// Initialize initial symbol.
-		if adinit == Empty {
-			adinit = g.board[i][sz-1-i]
-		}
-
-		// Different symbols means anti-diagonal sum is 0.
-		if g.board[i][sz-1-i] != adinit {
-			adsum = 0
-			break
-		}
-
-		if g.board[i][sz-1-i] == player {
-			adsum += 1 // Increment for player
-		} else {
-			adsum -= 1 // Decrement for opponent
-		}
-	}
+// decisiveWindowScore is returned for a window a player has already won
+// (k marks in a row). It must dominate the sum of every other window's
+// score, which maxes out at 1000 per window (a k-1 threat), so a completed
+// win always outweighs any number of open threats elsewhere on the board.
+const decisiveWindowScore = 1 << 20
+
+// searchBound returns an alpha-beta window half-width guaranteed to exceed
+// the magnitude of outcome on g, for use as the initial ±lim passed to the
+// root of a search (alphaBetaRoot, AlphaBetaParallel, iterativeDeepening).
+// outcome sums one windowMarkScore per k-length window on the board, each at
+// most decisiveWindowScore, over at most rows*cols*4 windows (4 directions
+// per cell, generously counting windows that don't actually fit on the
+// board), so that product is always a safe bound.
+func searchBound(g Game) int {
+	return decisiveWindowScore*(g.rows*g.cols*4) + 1
+}
 
-	if adsum == g.size {
-		return 3 * g.size * g.size
-	} else if adsum == -g.size {
-		return -(3 * g.size * g.size)
+// windowMarkScore maps the number of a single player's marks in a k-long
+// window (with no opponent marks in it) to a heuristic score: 1, 2 and 3
+// marks score 1, 10 and 100 respectively, one short of winning the window
+// (k-1 marks) scores 1000, and a won window scores decisively.
+func windowMarkScore(marks, k int) int {
+	switch {
+	case marks >= k:
+		return decisiveWindowScore
+	case marks == k-1:
+		return 1000
+	case marks == 3:
+		return 100
+	case marks == 2:
+		return 10
+	case marks == 1:
+		return 1
+	default:
+		return marks
 	}
-
-	sum += adsum
-
-	return
 }
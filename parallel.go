@@ -0,0 +1,215 @@
+/*
+file: parallel.go
+author: alemedeiros <alexandre.n.medeiros _at_ gmail.com>
+
+A parallel alpha-beta search using a Young Brothers Wait style scheme: the
+root's candidate moves are searched concurrently by a bounded pool of
+workers that share their alpha/beta bounds, so a cutoff discovered by one
+worker lets its siblings abandon their own search early.
+*/
+
+package nnc
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+)
+
+// sharedBounds is a mutex-guarded alpha/beta window shared by the sibling
+// workers of a parallel search, so a bound tightened by one is visible to
+// the others.
+type sharedBounds struct {
+	mu          sync.Mutex
+	alpha, beta int
+}
+
+func newSharedBounds(alpha, beta int) *sharedBounds {
+	return &sharedBounds{alpha: alpha, beta: beta}
+}
+
+// get returns a snapshot of the current bounds.
+func (b *sharedBounds) get() (alpha, beta int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.alpha, b.beta
+}
+
+// raiseAlpha tightens the shared lower bound if v improves on it, and
+// reports whether the window is now closed (alpha >= beta), meaning siblings
+// can stop searching.
+func (b *sharedBounds) raiseAlpha(v int) (closed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if v > b.alpha {
+		b.alpha = v
+	}
+
+	return b.beta <= b.alpha
+}
+
+// AlphaBetaParallel searches g to depth using one worker per candidate move
+// at the root, bounded by runtime.NumCPU() workers. Workers share their
+// alpha/beta bounds through a sharedBounds; as soon as one raises alpha past
+// beta, ctx is cancelled so the remaining workers abandon their recursion
+// early instead of running to completion.
+func AlphaBetaParallel(g Game, depth int, player byte, ctx context.Context) move {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	lim := searchBound(g)
+	bounds := newSharedBounds(-lim, lim)
+
+	sem := make(chan struct{}, runtime.NumCPU())
+	var wg sync.WaitGroup
+	var resMu sync.Mutex
+	best := move{-lim - 1, -1, -1}
+	haveBest := false
+
+	for i, l := range g.board {
+		for j, e := range l {
+			if e != Empty {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				continue
+			default:
+			}
+
+			i, j := i, j
+
+			sem <- struct{}{}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				ng := g.copyGame()
+				ng.Play(i, j, player)
+
+				alpha, beta := bounds.get()
+				m := alphaBetaPruningSerialCtx(ctx, ng, depth-1, alpha, beta, i, j, player)
+
+				// A cancelled worker's m is outcome() of a node it gave up
+				// on before reaching a terminal position or the depth
+				// limit, not a genuine search result; discard it so it
+				// can't clobber a sibling's real best move.
+				if ctx.Err() != nil {
+					return
+				}
+
+				// m's coordinates are the opponent's reply chosen below the
+				// root; report the root move this worker searched instead.
+				m.i, m.j = i, j
+
+				resMu.Lock()
+				if !haveBest || m.value > best.value {
+					best, haveBest = m, true
+				}
+				resMu.Unlock()
+
+				if bounds.raiseAlpha(m.value) {
+					cancel()
+				}
+			}()
+		}
+	}
+
+	wg.Wait()
+
+	return best
+}
+
+// alphaBetaPruningSerialCtx is alphaBetaPruningSerial with a context check at
+// the top of every call, so a parallel search's workers can abandon their
+// recursion as soon as a sibling closes the shared alpha/beta window.
+func alphaBetaPruningSerialCtx(ctx context.Context, g Game, depth int, alpha, beta int, x, y int, player byte) move {
+	select {
+	case <-ctx.Done():
+		return move{g.outcome(player), x, y}
+	default:
+	}
+
+	if depth == 0 {
+		return move{g.outcome(player), x, y}
+	}
+	if done, _ := g.isDone(); done {
+		return move{g.outcome(player), x, y}
+	}
+
+	if curr := g.currPlayer; curr == player {
+		p := move{alpha, x, y}
+
+		for i, l := range g.board {
+			for j, e := range l {
+				if e != Empty {
+					continue
+				}
+
+				ng := g.copyGame()
+				ng.Play(i, j, player)
+
+				m := alphaBetaPruningSerialCtx(ctx, ng, depth-1, alpha, beta, i, j, player)
+				m.i = i
+				m.j = j
+
+				p = max(p, m)
+				alpha = p.value
+
+				if beta <= alpha {
+					return m
+				}
+			}
+		}
+
+		return p
+	}
+
+	p := move{beta, x, y}
+
+	for i, l := range g.board {
+		for j, e := range l {
+			if e != Empty {
+				continue
+			}
+
+			ng := g.copyGame()
+			ng.Play(i, j, g.currPlayer)
+
+			m := alphaBetaPruningSerialCtx(ctx, ng, depth-1, alpha, beta, i, j, player)
+			m.i = i
+			m.j = j
+
+			p = min(p, m)
+			beta = p.value
+
+			if beta <= alpha {
+				return m
+			}
+		}
+	}
+
+	return p
+}
+
+// PlayAIParallel method checks if it is the given player's turn, if so, it
+// makes a move as that player using AlphaBetaParallel.
+//
+// Return true and winner (Empty means draw) if the move ended the game.
+func (g *Game) PlayAIParallel(player byte) (done bool, winner byte, err error) {
+	if g.currPlayer != player {
+		return false, Empty, errors.New("not player's turn")
+	}
+
+	m := AlphaBetaParallel(*g, g.rows*g.cols, player, context.Background())
+
+	return g.Play(m.i, m.j, player)
+}
@@ -0,0 +1,48 @@
+package nnc
+
+import (
+	"context"
+	"testing"
+)
+
+// searchDepth is used by the benchmarks below instead of a full-depth search
+// so that 5x5 boards stay benchmarkable; it's deep enough to exercise
+// several plies of branching and pruning.
+const searchDepth = 4
+
+func benchmarkAlphaBetaSerial(b *testing.B, sz int) {
+	lim := sz * sz * 10
+
+	for i := 0; i < b.N; i++ {
+		g := New(sz)
+		alphaBetaPruningSerial(g, searchDepth, -lim, lim, -1, -1, Cross)
+	}
+}
+
+func benchmarkAlphaBetaParallel(b *testing.B, sz int) {
+	for i := 0; i < b.N; i++ {
+		g := New(sz)
+		AlphaBetaParallel(g, searchDepth, Cross, context.Background())
+	}
+}
+
+func BenchmarkAlphaBetaSerial4x4(b *testing.B)   { benchmarkAlphaBetaSerial(b, 4) }
+func BenchmarkAlphaBetaParallel4x4(b *testing.B) { benchmarkAlphaBetaParallel(b, 4) }
+
+func BenchmarkAlphaBetaSerial5x5(b *testing.B)   { benchmarkAlphaBetaSerial(b, 5) }
+func BenchmarkAlphaBetaParallel5x5(b *testing.B) { benchmarkAlphaBetaParallel(b, 5) }
+
+func TestAlphaBetaParallelFindsWin(t *testing.T) {
+	// X to move with two in a row and an open third cell: the parallel
+	// search should find the immediate win.
+	g := New(3)
+	g.Play(0, 0, Cross)
+	g.Play(1, 0, Nought)
+	g.Play(0, 1, Cross)
+	g.Play(1, 1, Nought)
+
+	m := AlphaBetaParallel(g, g.rows*g.cols, Cross, context.Background())
+	if m.i != 0 || m.j != 2 {
+		t.Fatalf("expected winning move (0, 2), got (%d, %d)", m.i, m.j)
+	}
+}
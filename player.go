@@ -0,0 +1,39 @@
+/*
+file: player.go
+author: alemedeiros <alexandre.n.medeiros _at_ gmail.com>
+
+The Player interface, letting alternative engines (e.g. the menace
+subpackage) play against or train alongside the alpha-beta AI.
+*/
+
+package nnc
+
+// Player is implemented by anything able to choose a move for a Game without
+// mutating it. It lets different engines (alpha-beta search, MENACE, ...)
+// play against each other through a common interface.
+type Player interface {
+	// Move returns the coordinates Player would play next on g. g's
+	// CurrentPlayer identifies which side Move should play for.
+	Move(g Game) (x, y int)
+}
+
+// AlphaBetaPlayer is a Player backed by the serial alpha-beta search. It
+// always returns the deterministic best move, equivalent to Hard difficulty.
+type AlphaBetaPlayer struct{}
+
+// Move implements the Player interface. On a terminal or full board, where
+// there is no legal move to return, it reports (-1, -1).
+func (AlphaBetaPlayer) Move(g Game) (x, y int) {
+	if done, _ := g.isDone(); done {
+		return -1, -1
+	}
+
+	moves := alphaBetaRoot(g, g.CurrentPlayer())
+	if len(moves) == 0 {
+		return -1, -1
+	}
+
+	m := moves[0]
+
+	return m.i, m.j
+}
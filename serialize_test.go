@@ -0,0 +1,97 @@
+package nnc
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestStringLoadGameRoundTrip(t *testing.T) {
+	g := New(3)
+	g.Play(0, 0, Cross)
+	g.Play(1, 1, Nought)
+	g.Play(0, 1, Cross)
+
+	s := g.String()
+
+	loaded, err := LoadGame(s)
+	if err != nil {
+		t.Fatalf("LoadGame: %v", err)
+	}
+	if loaded.String() != s {
+		t.Fatalf("round-trip mismatch: got %q, want %q", loaded.String(), s)
+	}
+}
+
+func TestLoadGameFindsKnownBestMove(t *testing.T) {
+	// X has two in a row on the top row and an open third cell: loading this
+	// position directly should let PlayAIDifficulty(Hard) find the win.
+	g, err := LoadGame("nnc/3x3x3;X;XX./OO./...")
+	if err != nil {
+		t.Fatalf("LoadGame: %v", err)
+	}
+
+	done, winner, err := g.PlayAIDifficulty(Cross, Hard)
+	if err != nil {
+		t.Fatalf("PlayAIDifficulty: %v", err)
+	}
+	if !done || winner != Cross {
+		t.Fatalf("expected Cross to find the winning move, got done=%v winner=%q", done, winner)
+	}
+}
+
+func TestLoadGameRejectsInconsistentCounts(t *testing.T) {
+	if _, err := LoadGame("nnc/3x3x3;X;XXX/.../..."); err == nil {
+		t.Fatal("expected error for inconsistent cross/nought counts")
+	}
+}
+
+func TestLoadGameRejectsDoubleWin(t *testing.T) {
+	if _, err := LoadGame("nnc/3x3x3;X;XXX/OOO/..."); err == nil {
+		t.Fatal("expected error when both players have won")
+	}
+}
+
+func TestLoadGameRejectsWrongCurrentPlayer(t *testing.T) {
+	// One cross played: it should be Nought's turn, not Cross's.
+	if _, err := LoadGame("nnc/3x3x3;X;X../.../..."); err == nil {
+		t.Fatal("expected error for current player inconsistent with move counts")
+	}
+}
+
+func TestGameJSONRoundTrip(t *testing.T) {
+	g := New(3)
+	g.Play(0, 0, Cross)
+	g.Play(1, 1, Nought)
+
+	data, err := json.Marshal(g)
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+
+	var loaded Game
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+
+	if loaded.String() != g.String() {
+		t.Fatalf("JSON round-trip mismatch: got %q, want %q", loaded.String(), g.String())
+	}
+}
+
+func TestRecordReplay(t *testing.T) {
+	r := Record{Rows: 3, Cols: 3, K: 3, Moves: [][2]int{{0, 0}, {1, 1}, {0, 1}}}
+
+	g, err := r.Replay()
+	if err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+
+	want := New(3)
+	want.Play(0, 0, Cross)
+	want.Play(1, 1, Nought)
+	want.Play(0, 1, Cross)
+
+	if g.String() != want.String() {
+		t.Fatalf("Replay mismatch: got %q, want %q", g.String(), want.String())
+	}
+}
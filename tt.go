@@ -0,0 +1,168 @@
+/*
+file: tt.go
+author: alemedeiros <alexandre.n.medeiros _at_ gmail.com>
+
+A transposition table for the alpha-beta search, keyed by the Zobrist hash
+maintained incrementally on Game.
+*/
+
+package nnc
+
+import "sync"
+
+// ttFlag records whether a ttEntry's value is exact or only a bound, the way
+// it was found under the window alpha-beta searched it with.
+type ttFlag int
+
+const (
+	ttExact ttFlag = iota
+	ttLowerBound
+	ttUpperBound
+)
+
+// ttEntry is what the transposition table remembers about a position.
+type ttEntry struct {
+	depth    int
+	value    int
+	flag     ttFlag
+	bestMove [2]int
+}
+
+// transpositionTable is a concurrency-safe map from Zobrist hash to ttEntry.
+type transpositionTable struct {
+	mu      sync.RWMutex
+	entries map[uint64]ttEntry
+}
+
+func newTranspositionTable() *transpositionTable {
+	return &transpositionTable{entries: make(map[uint64]ttEntry)}
+}
+
+func (t *transpositionTable) get(key uint64) (ttEntry, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	e, ok := t.entries[key]
+
+	return e, ok
+}
+
+func (t *transpositionTable) put(key uint64, e ttEntry) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.entries[key] = e
+}
+
+// orderedMoves returns g's legal moves, with ttBest moved to the front when
+// present, so the search tries the previously best-known move first.
+func orderedMoves(g Game, ttBest [2]int, haveTTBest bool) [][2]int {
+	moves := g.legalMoves()
+	if !haveTTBest {
+		return moves
+	}
+
+	for k, m := range moves {
+		if m == ttBest {
+			moves[0], moves[k] = moves[k], moves[0]
+			break
+		}
+	}
+
+	return moves
+}
+
+// alphaBetaTT is alphaBetaPruningSerial augmented with transposition-table
+// lookups, cutoffs and move ordering.
+func alphaBetaTT(g Game, depth, alpha, beta, x, y int, player byte, tt *transpositionTable) move {
+	if depth == 0 {
+		return move{g.outcome(player), x, y}
+	}
+	if done, _ := g.isDone(); done {
+		return move{g.outcome(player), x, y}
+	}
+
+	origAlpha, origBeta := alpha, beta
+	key := g.hash
+
+	var ttBest [2]int
+	haveTTBest := false
+
+	if e, ok := tt.get(key); ok {
+		ttBest, haveTTBest = e.bestMove, true
+
+		if e.depth >= depth {
+			switch e.flag {
+			case ttExact:
+				return move{e.value, x, y}
+			case ttLowerBound:
+				if e.value > alpha {
+					alpha = e.value
+				}
+			case ttUpperBound:
+				if e.value < beta {
+					beta = e.value
+				}
+			}
+			if alpha >= beta {
+				return move{e.value, x, y}
+			}
+		}
+	}
+
+	moves := orderedMoves(g, ttBest, haveTTBest)
+	curr := g.currPlayer
+
+	var p move
+	if curr == player {
+		p = move{alpha, x, y}
+
+		for _, mv := range moves {
+			i, j := mv[0], mv[1]
+
+			ng := g.copyGame()
+			ng.Play(i, j, player)
+
+			m := alphaBetaTT(ng, depth-1, alpha, beta, i, j, player, tt)
+			m.i, m.j = i, j
+
+			p = max(p, m)
+			alpha = p.value
+
+			if beta <= alpha {
+				break
+			}
+		}
+	} else {
+		p = move{beta, x, y}
+
+		for _, mv := range moves {
+			i, j := mv[0], mv[1]
+
+			ng := g.copyGame()
+			ng.Play(i, j, curr)
+
+			m := alphaBetaTT(ng, depth-1, alpha, beta, i, j, player, tt)
+			m.i, m.j = i, j
+
+			p = min(p, m)
+			beta = p.value
+
+			if beta <= alpha {
+				break
+			}
+		}
+	}
+
+	flag := ttExact
+	switch {
+	case p.value <= origAlpha:
+		flag = ttUpperBound
+	case p.value >= origBeta:
+		flag = ttLowerBound
+	}
+
+	tt.put(key, ttEntry{depth: depth, value: p.value, flag: flag, bestMove: [2]int{p.i, p.j}})
+
+	return p
+}
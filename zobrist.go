@@ -0,0 +1,41 @@
+/*
+file: zobrist.go
+author: alemedeiros <alexandre.n.medeiros _at_ gmail.com>
+
+Zobrist hashing, used to key the transposition table consulted by the
+iterative-deepening search.
+*/
+
+package nnc
+
+import "math/rand"
+
+// zobristSeed seeds the random table generated for every new Game, so that
+// two Games of the same dimensions always hash the same way.
+const zobristSeed = 0x5eed1e55
+
+// newZobristTable precomputes a [rows][cols][2]uint64 table of random
+// 64-bit values, one per (row, col, player) triple, deterministically seeded
+// so every board of the same size gets an identical table.
+func newZobristTable(rows, cols int) [][][2]uint64 {
+	r := rand.New(rand.NewSource(zobristSeed))
+
+	t := make([][][2]uint64, rows)
+	for i := range t {
+		t[i] = make([][2]uint64, cols)
+		for j := range t[i] {
+			t[i][j] = [2]uint64{r.Uint64(), r.Uint64()}
+		}
+	}
+
+	return t
+}
+
+// playerIndex maps a player byte to its zobrist table index.
+func playerIndex(player byte) int {
+	if player == Nought {
+		return 1
+	}
+
+	return 0
+}